@@ -0,0 +1,199 @@
+package gosoup
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html/charset"
+)
+
+// Client fetches and parses HTML pages, keeping cookies and headers across
+// calls the way a browser session would.
+type Client struct {
+	HTTPClient *http.Client
+	Headers    map[string]string
+	MaxRetries int
+	Backoff    time.Duration
+}
+
+func NewClient() *Client {
+	jar, _ := cookiejar.New(nil)
+
+	return &Client{
+		HTTPClient: &http.Client{Jar: jar, Timeout: 30 * time.Second},
+		Headers:    make(map[string]string),
+		MaxRetries: 3,
+		Backoff:    500 * time.Millisecond,
+	}
+}
+
+func (c *Client) SetHeader(key, value string) {
+	c.Headers[key] = value
+}
+
+func (c *Client) SetCookie(rawurl string, cookie *http.Cookie) error {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return newError(ErrCreatingGetRequest, fmt.Sprintf("unable to parse url `%s`", rawurl))
+	}
+
+	c.HTTPClient.Jar.SetCookies(u, []*http.Cookie{cookie})
+
+	return nil
+}
+
+func (c *Client) SetTimeout(d time.Duration) {
+	c.HTTPClient.Timeout = d
+}
+
+func (c *Client) SetTransport(rt http.RoundTripper) {
+	c.HTTPClient.Transport = rt
+}
+
+func (c *Client) Get(rawurl string) (*Root, error) {
+	req, err := http.NewRequest(http.MethodGet, rawurl, nil)
+	if err != nil {
+		return nil, newError(ErrCreatingGetRequest, fmt.Sprintf("unable to create GET request for `%s`", rawurl))
+	}
+
+	c.applyHeaders(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, newError(ErrInGetRequest, fmt.Sprintf("GET request to `%s` failed: %s", rawurl, err))
+	}
+	defer resp.Body.Close()
+
+	return c.parseResponse(resp)
+}
+
+func (c *Client) PostForm(rawurl string, values url.Values) (*Root, error) {
+	req, err := http.NewRequest(http.MethodPost, rawurl, strings.NewReader(values.Encode()))
+	if err != nil {
+		return nil, newError(ErrCreatingPostRequest, fmt.Sprintf("unable to create POST request for `%s`", rawurl))
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	c.applyHeaders(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, newError(ErrInPostRequest, fmt.Sprintf("POST request to `%s` failed: %s", rawurl, err))
+	}
+	defer resp.Body.Close()
+
+	return c.parseResponse(resp)
+}
+
+func (c *Client) PostJSON(rawurl string, v interface{}) (*Root, error) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return nil, newError(ErrCreatingPostRequest, "unable to encode JSON body")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, rawurl, bytes.NewReader(body))
+	if err != nil {
+		return nil, newError(ErrCreatingPostRequest, fmt.Sprintf("unable to create POST request for `%s`", rawurl))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	c.applyHeaders(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, newError(ErrInPostRequest, fmt.Sprintf("POST request to `%s` failed: %s", rawurl, err))
+	}
+	defer resp.Body.Close()
+
+	return c.parseResponse(resp)
+}
+
+func (c *Client) applyHeaders(req *http.Request) {
+	for k, v := range c.Headers {
+		req.Header.Set(k, v)
+	}
+}
+
+// do sends req, retrying with exponential backoff on network errors and
+// 5xx responses, up to c.MaxRetries additional attempts. Each retry gets a
+// fresh copy of req with its body rewound via GetBody, since the previous
+// attempt may have fully drained it.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	var resp *http.Response
+	var err error
+
+	wait := c.Backoff
+	for attempt := 0; attempt <= c.MaxRetries; attempt++ {
+		attemptReq := req
+		if attempt > 0 && req.GetBody != nil {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return nil, bodyErr
+			}
+			clone := req.Clone(req.Context())
+			clone.Body = body
+			attemptReq = clone
+		}
+
+		resp, err = c.HTTPClient.Do(attemptReq)
+		if err == nil && resp.StatusCode < 500 {
+			return resp, nil
+		}
+		if err == nil {
+			resp.Body.Close()
+		}
+		if attempt == c.MaxRetries {
+			break
+		}
+		time.Sleep(wait)
+		wait *= 2
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Client) parseResponse(resp *http.Response) (*Root, error) {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, newError(ErrReadingResponse, "unable to read response body")
+	}
+
+	utf8Reader, err := charset.NewReader(bytes.NewReader(body), resp.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, newError(ErrReadingResponse, "unable to detect response charset")
+	}
+
+	decoded, err := io.ReadAll(utf8Reader)
+	if err != nil {
+		return nil, newError(ErrReadingResponse, "unable to decode response body")
+	}
+
+	return HTMLParse(string(decoded))
+}
+
+var defaultClient = NewClient()
+
+func Get(rawurl string) (*Root, error) {
+	return defaultClient.Get(rawurl)
+}
+
+func Post(rawurl string, values url.Values) (*Root, error) {
+	return defaultClient.PostForm(rawurl, values)
+}
+
+func SetHeader(key, value string) {
+	defaultClient.SetHeader(key, value)
+}
+
+func SetCookie(rawurl string, cookie *http.Cookie) error {
+	return defaultClient.SetCookie(rawurl, cookie)
+}
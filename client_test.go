@@ -0,0 +1,79 @@
+package gosoup
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientGet(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "gosoup-test", r.Header.Get("X-Test"))
+		w.Write([]byte(`<html><body><h1>Hello</h1></body></html>`))
+	}))
+	defer srv.Close()
+
+	c := NewClient()
+	c.SetHeader("X-Test", "gosoup-test")
+
+	root, err := c.Get(srv.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, "Hello", root.Find("h1").Text())
+}
+
+func TestClientPostForm(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		w.Write([]byte(`<html><body><p>` + r.FormValue("name") + `</p></body></html>`))
+	}))
+	defer srv.Close()
+
+	c := NewClient()
+	root, err := c.PostForm(srv.URL, url.Values{"name": {"world"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "world", root.Find("p").Text())
+}
+
+func TestClientCookiePersistsAcrossRequests(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie("session")
+		if err != nil {
+			w.Write([]byte(`<html><body><p>none</p></body></html>`))
+			return
+		}
+		w.Write([]byte(`<html><body><p>` + cookie.Value + `</p></body></html>`))
+	}))
+	defer srv.Close()
+
+	c := NewClient()
+	assert.Nil(t, c.SetCookie(srv.URL, &http.Cookie{Name: "session", Value: "abc123"}))
+
+	root, err := c.Get(srv.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, "abc123", root.Find("p").Text())
+}
+
+func TestClientRetriesOn5xx(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`<html><body><p>ok</p></body></html>`))
+	}))
+	defer srv.Close()
+
+	c := NewClient()
+	c.Backoff = time.Millisecond
+
+	root, err := c.Get(srv.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, "ok", root.Find("p").Text())
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+}
@@ -0,0 +1,109 @@
+package gosoup
+
+import (
+	"sync"
+
+	"golang.org/x/net/html"
+)
+
+// FindAllParallel behaves like FindAll but fans the search for r's direct
+// children out across up to workers goroutines, merging matches back in
+// document order. It is intended for large trees where a single-threaded
+// findAll becomes the bottleneck.
+func (r Root) FindAllParallel(workers int, args ...string) ([]*Root, error) {
+	if r.Node == nil {
+		return nil, errNodeElementEmpty
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	var children []*html.Node
+	for c := r.Node.FirstChild; c != nil; c = c.NextSibling {
+		children = append(children, c)
+	}
+
+	matches := make([][]*html.Node, len(children))
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+
+	for i, c := range children {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, c *html.Node) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			matches[i] = findAllInclusive(c, args, false)
+		}(i, c)
+	}
+	wg.Wait()
+
+	var nodes []*html.Node
+	for _, m := range matches {
+		nodes = append(nodes, m...)
+	}
+
+	if len(nodes) == 0 {
+		return nil, newErrorAttrs(ErrElementNotFound, args)
+	}
+
+	roots := make([]*Root, 0, len(nodes))
+	for _, n := range nodes {
+		roots = append(roots, &Root{Node: n, Value: n.Data})
+	}
+
+	return roots, nil
+}
+
+// findAllInclusive is findAll's matching rule applied to n itself as well
+// as its descendants, so callers can fan the search out per-subtree.
+func findAllInclusive(n *html.Node, args []string, strict bool) []*html.Node {
+	var nodes []*html.Node
+
+	if n.Type == html.ElementNode && matchElementName(n, args[0]) {
+		if len(args) > 1 && len(args) < 4 {
+			for i := 0; i < len(n.Attr); i++ {
+				attr := n.Attr[i]
+				if (strict && attributeAndValueEquals(attr, args[1], args[2])) ||
+					(!strict && attributeContainsValue(attr, args[1], args[2])) {
+					nodes = append(nodes, n)
+					break
+				}
+			}
+		} else if len(args) == 1 {
+			nodes = append(nodes, n)
+		}
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		nodes = append(nodes, findAllInclusive(c, args, strict)...)
+	}
+
+	return nodes
+}
+
+// Walk visits every descendant element of r in document order, stopping
+// early if visit returns false.
+func (r Root) Walk(visit func(*Root) bool) {
+	if r.Node == nil {
+		return
+	}
+
+	var walk func(*html.Node) bool
+	walk = func(n *html.Node) bool {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type == html.ElementNode {
+				if !visit(&Root{Node: c, Value: c.Data}) {
+					return false
+				}
+			}
+			if !walk(c) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	walk(r.Node)
+}
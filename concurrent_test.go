@@ -0,0 +1,105 @@
+package gosoup
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func bigHTML(n int) string {
+	var b strings.Builder
+	b.WriteString("<html><body>")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, `<div class="item" data-i="%d"><span>%d</span></div>`, i, i)
+	}
+	b.WriteString("</body></html>")
+
+	return b.String()
+}
+
+func TestFindAllParallelMatchesFindAll(t *testing.T) {
+	doc, err := HTMLParse(bigHTML(2000))
+	assert.Nil(t, err)
+
+	sequential, err := doc.FindAll("div")
+	assert.Nil(t, err)
+
+	parallel, err := doc.FindAllParallel(8, "div")
+	assert.Nil(t, err)
+
+	assert.Equal(t, len(sequential), len(parallel))
+	for i := range sequential {
+		assert.Equal(t, sequential[i].Attrs()["data-i"], parallel[i].Attrs()["data-i"])
+	}
+}
+
+func TestFindAllParallelNoMatch(t *testing.T) {
+	doc, err := HTMLParse(bigHTML(10))
+	assert.Nil(t, err)
+
+	_, err = doc.FindAllParallel(4, "section")
+	assert.NotNil(t, err)
+}
+
+func TestFindAllParallelRace(t *testing.T) {
+	doc, err := HTMLParse(bigHTML(20000))
+	assert.Nil(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := doc.FindAllParallel(4, "div")
+			assert.Nil(t, err)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestWalkEarlyTermination(t *testing.T) {
+	doc, err := HTMLParse(bigHTML(100))
+	assert.Nil(t, err)
+
+	var visited int
+	doc.Walk(func(r *Root) bool {
+		visited++
+		return r.Attrs()["data-i"] != "5"
+	})
+
+	assert.True(t, visited > 0 && visited < 50)
+}
+
+func BenchmarkFindAllSequential(b *testing.B) {
+	// 40000 items (80000 elements) is a couple MB of markup. FindAllParallel
+	// on doc fans out over html's direct children, head and body, so nearly
+	// all of its search runs in a single goroutine.
+	doc, err := HTMLParse(bigHTML(40000))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := doc.FindAll("div"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkFindAllParallel(b *testing.B) {
+	doc, err := HTMLParse(bigHTML(40000))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := doc.FindAllParallel(8, "div"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
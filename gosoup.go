@@ -19,6 +19,12 @@ const (
 	ErrNoPreviousSibling
 	ErrNoNextElementSibling
 	ErrNoPreviousElementSibling
+	ErrInvalidSelector
+	ErrCreatingGetRequest
+	ErrInGetRequest
+	ErrCreatingPostRequest
+	ErrInPostRequest
+	ErrReadingResponse
 )
 
 type Error struct {
@@ -42,6 +48,9 @@ var (
 	errNodeElementEmpty = newError(ErrNodeElementEmpty, fmt.Sprintf("node element empty"))
 )
 
+// Root is safe for concurrent reads by multiple goroutines once HTMLParse
+// has returned; mutating methods (see mutate.go) are not synchronized and
+// must not race with reads of the same tree.
 type Root struct {
 	Node  *html.Node
 	Value string
@@ -104,7 +113,7 @@ func (r Root) FindStrict(args ...string) *Root {
 		return &Root{Error: errNodeElementEmpty}
 	}
 
-	n, ok := findOne(r.Node, args, false, false)
+	n, ok := findOne(r.Node, args, false, true)
 	if !ok {
 		return &Root{Error: newErrorAttrs(ErrElementNotFound, args)}
 	}
@@ -318,7 +327,7 @@ func findOne(n *html.Node, args []string, uni, strict bool) (*html.Node, bool) {
 					attr := n.Attr[i]
 					searchAttrName := args[1]
 					searchAttrVal := args[2]
-					if (strict && attributeAndValueEquals(attr, searchAttrName, searchAttrName)) ||
+					if (strict && attributeAndValueEquals(attr, searchAttrName, searchAttrVal)) ||
 						(!strict && attributeContainsValue(attr, searchAttrName, searchAttrVal)) {
 						return n, true
 					}
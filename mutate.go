@@ -0,0 +1,216 @@
+package gosoup
+
+import (
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// NewElement builds a detached element node, ready to be inserted into a
+// tree with AppendChild, PrependChild, InsertBefore or InsertAfter.
+func NewElement(tag string, attrs map[string]string) *Root {
+	n := &html.Node{
+		Type: html.ElementNode,
+		Data: tag,
+	}
+	for k, v := range attrs {
+		n.Attr = append(n.Attr, html.Attribute{Key: k, Val: v})
+	}
+
+	return &Root{Node: n, Value: tag}
+}
+
+func (r Root) SetAttr(key, value string) {
+	for i := range r.Node.Attr {
+		if r.Node.Attr[i].Key == key {
+			r.Node.Attr[i].Val = value
+			return
+		}
+	}
+	r.Node.Attr = append(r.Node.Attr, html.Attribute{Key: key, Val: value})
+}
+
+func (r Root) RemoveAttr(key string) {
+	kept := r.Node.Attr[:0]
+	for _, a := range r.Node.Attr {
+		if a.Key != key {
+			kept = append(kept, a)
+		}
+	}
+	r.Node.Attr = kept
+}
+
+func (r Root) HasClass(class string) bool {
+	return hasClassValue(r.Attrs()["class"], class)
+}
+
+func (r Root) AddClass(class string) {
+	if r.HasClass(class) {
+		return
+	}
+
+	classes := append(strings.Fields(r.Attrs()["class"]), class)
+	r.SetAttr("class", strings.Join(classes, " "))
+}
+
+func (r Root) RemoveClass(class string) {
+	classes := strings.Fields(r.Attrs()["class"])
+	kept := classes[:0]
+	for _, c := range classes {
+		if c != class {
+			kept = append(kept, c)
+		}
+	}
+	r.SetAttr("class", strings.Join(kept, " "))
+}
+
+func (r Root) SetText(s string) {
+	r.removeChildren()
+	r.Node.AppendChild(&html.Node{Type: html.TextNode, Data: s})
+}
+
+// SetHTML parses s as an HTML fragment and replaces r's children with it.
+func (r Root) SetHTML(s string) error {
+	nodes, err := html.ParseFragment(strings.NewReader(s), r.Node)
+	if err != nil {
+		return newError(ErrUnableToParse, "unable to parse the HTML fragment")
+	}
+
+	r.removeChildren()
+	for _, n := range nodes {
+		r.Node.AppendChild(n)
+	}
+
+	return nil
+}
+
+func (r Root) removeChildren() {
+	for c := r.Node.FirstChild; c != nil; {
+		next := c.NextSibling
+		r.Node.RemoveChild(c)
+		c = next
+	}
+}
+
+// detachNode removes n from its current parent, if any, so it can be
+// attached elsewhere. html.Node.AppendChild/InsertBefore panic if handed a
+// node that is still attached, which any *Root obtained via Find/Select is.
+func detachNode(n *html.Node) {
+	if n.Parent != nil {
+		n.Parent.RemoveChild(n)
+	}
+}
+
+func (r Root) AppendChild(child *Root) {
+	detachNode(child.Node)
+	r.Node.AppendChild(child.Node)
+}
+
+func (r Root) PrependChild(child *Root) {
+	detachNode(child.Node)
+	if r.Node.FirstChild == nil {
+		r.Node.AppendChild(child.Node)
+		return
+	}
+	r.Node.InsertBefore(child.Node, r.Node.FirstChild)
+}
+
+// InsertBefore inserts sibling immediately before r among r's parent's
+// children. It is a no-op if r has no parent.
+func (r Root) InsertBefore(sibling *Root) {
+	if r.Node.Parent == nil {
+		return
+	}
+	detachNode(sibling.Node)
+	r.Node.Parent.InsertBefore(sibling.Node, r.Node)
+}
+
+// InsertAfter inserts sibling immediately after r among r's parent's
+// children. It is a no-op if r has no parent.
+func (r Root) InsertAfter(sibling *Root) {
+	if r.Node.Parent == nil {
+		return
+	}
+	detachNode(sibling.Node)
+	if r.Node.NextSibling == nil {
+		r.Node.Parent.AppendChild(sibling.Node)
+		return
+	}
+	r.Node.Parent.InsertBefore(sibling.Node, r.Node.NextSibling)
+}
+
+// Wrap parses tagHTML as a single wrapper element and inserts it in r's
+// place, moving r inside it.
+func (r Root) Wrap(tagHTML string) error {
+	parent := r.Node.Parent
+	if parent == nil {
+		return newError(ErrNodeElementEmpty, "cannot wrap a node without a parent")
+	}
+
+	nodes, err := html.ParseFragment(strings.NewReader(tagHTML), parent)
+	if err != nil || len(nodes) == 0 {
+		return newError(ErrUnableToParse, "unable to parse the wrapper HTML")
+	}
+
+	wrapper := nodes[0]
+	parent.InsertBefore(wrapper, r.Node)
+	parent.RemoveChild(r.Node)
+	wrapper.AppendChild(r.Node)
+
+	return nil
+}
+
+// Unwrap removes r, replacing it with its own children.
+func (r Root) Unwrap() {
+	parent := r.Node.Parent
+	if parent == nil {
+		return
+	}
+
+	for c := r.Node.FirstChild; c != nil; {
+		next := c.NextSibling
+		r.Node.RemoveChild(c)
+		parent.InsertBefore(c, r.Node)
+		c = next
+	}
+
+	parent.RemoveChild(r.Node)
+}
+
+func (r Root) Remove() {
+	if r.Node.Parent == nil {
+		return
+	}
+	r.Node.Parent.RemoveChild(r.Node)
+}
+
+// Clone returns a deep copy of r's underlying node subtree, since
+// html.Node pointers are otherwise shared with the original tree.
+func (r Root) Clone() *Root {
+	return &Root{Node: cloneNode(r.Node), Value: r.Value}
+}
+
+func cloneNode(n *html.Node) *html.Node {
+	if n == nil {
+		return nil
+	}
+
+	clone := &html.Node{
+		Type:      n.Type,
+		DataAtom:  n.DataAtom,
+		Data:      n.Data,
+		Namespace: n.Namespace,
+		Attr:      append([]html.Attribute(nil), n.Attr...),
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		clone.AppendChild(cloneNode(c))
+	}
+
+	return clone
+}
+
+func (r Root) Render(w io.Writer) error {
+	return html.Render(w, r.Node)
+}
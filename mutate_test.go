@@ -0,0 +1,158 @@
+package gosoup
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const mutateHTML = `
+<html>
+  <body>
+    <div id="main" class="box">
+      <p>Hello</p>
+    </div>
+  </body>
+</html>
+`
+
+func TestSetAttrAndRemoveAttr(t *testing.T) {
+	doc, err := HTMLParse(mutateHTML)
+	assert.Nil(t, err)
+
+	div := doc.Find("div")
+	div.SetAttr("data-role", "container")
+	assert.Equal(t, "container", div.Attrs()["data-role"])
+
+	div.SetAttr("id", "renamed")
+	assert.Equal(t, "renamed", div.Attrs()["id"])
+
+	div.RemoveAttr("data-role")
+	_, ok := div.Attrs()["data-role"]
+	assert.False(t, ok)
+}
+
+func TestClassHelpers(t *testing.T) {
+	doc, err := HTMLParse(mutateHTML)
+	assert.Nil(t, err)
+
+	div := doc.Find("div")
+	assert.True(t, div.HasClass("box"))
+
+	div.AddClass("active")
+	assert.True(t, div.HasClass("active"))
+	assert.True(t, div.HasClass("box"))
+
+	div.RemoveClass("box")
+	assert.False(t, div.HasClass("box"))
+	assert.True(t, div.HasClass("active"))
+}
+
+func TestSetTextAndSetHTML(t *testing.T) {
+	doc, err := HTMLParse(mutateHTML)
+	assert.Nil(t, err)
+
+	p := doc.Find("p")
+	p.SetText("Goodbye")
+	assert.Equal(t, "Goodbye", p.Text())
+
+	div := doc.Find("div")
+	assert.Nil(t, div.SetHTML(`<span>New content</span>`))
+	assert.Equal(t, "New content", div.Find("span").Text())
+}
+
+func TestAppendPrependChild(t *testing.T) {
+	doc, err := HTMLParse(mutateHTML)
+	assert.Nil(t, err)
+
+	div := doc.Find("div")
+	div.AppendChild(NewElement("em", nil))
+	div.PrependChild(NewElement("strong", nil))
+
+	children := div.Children()
+	assert.Equal(t, "strong", children[0].Value)
+	assert.Equal(t, "em", children[len(children)-1].Value)
+}
+
+func TestInsertBeforeAfter(t *testing.T) {
+	doc, err := HTMLParse(mutateHTML)
+	assert.Nil(t, err)
+
+	p := doc.Find("p")
+	p.InsertBefore(NewElement("h1", nil))
+	p.InsertAfter(NewElement("h2", nil))
+
+	h1 := doc.Find("h1")
+	assert.Equal(t, "p", h1.FindNextElementSibling().Value)
+	assert.Equal(t, "h2", doc.Find("p").FindNextElementSibling().Value)
+}
+
+func TestAppendChildMovesExistingNode(t *testing.T) {
+	doc, err := HTMLParse(`
+<html>
+  <body>
+    <div id="from"><span>Hi</span></div>
+    <div id="to"></div>
+  </body>
+</html>
+`)
+	assert.Nil(t, err)
+
+	span := doc.Find("span")
+	from, err := doc.SelectOne("#from")
+	assert.Nil(t, err)
+	to, err := doc.SelectOne("#to")
+	assert.Nil(t, err)
+
+	to.AppendChild(span)
+
+	assert.Equal(t, 0, len(from.Children()))
+	assert.Equal(t, "Hi", to.Find("span").Text())
+}
+
+func TestWrapUnwrap(t *testing.T) {
+	doc, err := HTMLParse(mutateHTML)
+	assert.Nil(t, err)
+
+	p := doc.Find("p")
+	assert.Nil(t, p.Wrap(`<section class="wrapper"></section>`))
+
+	section := doc.Find("section")
+	assert.True(t, section.HasClass("wrapper"))
+	assert.Equal(t, "Hello", section.Find("p").Text())
+
+	section.Unwrap()
+	_, err = doc.FindAll("section")
+	assert.NotNil(t, err)
+}
+
+func TestRemove(t *testing.T) {
+	doc, err := HTMLParse(mutateHTML)
+	assert.Nil(t, err)
+
+	doc.Find("p").Remove()
+	_, err = doc.FindAll("p")
+	assert.NotNil(t, err)
+}
+
+func TestCloneIsIndependent(t *testing.T) {
+	doc, err := HTMLParse(mutateHTML)
+	assert.Nil(t, err)
+
+	p := doc.Find("p")
+	clone := p.Clone()
+	clone.SetText("Changed")
+
+	assert.Equal(t, "Hello", p.Text())
+	assert.Equal(t, "Changed", clone.Text())
+}
+
+func TestRender(t *testing.T) {
+	doc, err := HTMLParse(mutateHTML)
+	assert.Nil(t, err)
+
+	var buf strings.Builder
+	assert.Nil(t, doc.Find("p").Render(&buf))
+	assert.Equal(t, "<p>Hello</p>", buf.String())
+}
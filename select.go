@@ -0,0 +1,557 @@
+package gosoup
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+type combinator int
+
+const (
+	combDescendant combinator = iota
+	combChild
+	combAdjacent
+	combGeneral
+)
+
+type attrMatcher struct {
+	name string
+	op   string
+	val  string
+}
+
+type pseudoMatcher struct {
+	name string
+	arg  string
+}
+
+type compoundSelector struct {
+	tag     string
+	id      string
+	classes []string
+	attrs   []attrMatcher
+	pseudos []pseudoMatcher
+}
+
+type selectorStep struct {
+	comb     combinator
+	compound compoundSelector
+}
+
+type selectorChain []selectorStep
+
+var anBRegexp = regexp.MustCompile(`^\s*([+-]?\d*)n\s*(?:([+-])\s*(\d+))?\s*$`)
+
+// Select returns every element matching the given CSS3 selector, in
+// document order, searching the descendants of r; r itself is not matched.
+func (r Root) Select(selector string) ([]*Root, error) {
+	if r.Node == nil {
+		return nil, errNodeElementEmpty
+	}
+
+	chains, err := parseSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[*html.Node]bool)
+	var matches []*html.Node
+
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if !seen[c] {
+				for _, chain := range chains {
+					if matchChain(c, chain) {
+						seen[c] = true
+						matches = append(matches, c)
+						break
+					}
+				}
+			}
+			walk(c)
+		}
+	}
+	walk(r.Node)
+
+	if len(matches) == 0 {
+		return nil, newError(ErrElementNotFound, fmt.Sprintf("no element matches selector `%s`", selector))
+	}
+
+	roots := make([]*Root, 0, len(matches))
+	for _, m := range matches {
+		roots = append(roots, &Root{Node: m, Value: m.Data})
+	}
+
+	return roots, nil
+}
+
+// SelectOne returns the first element matching the given CSS3 selector.
+func (r Root) SelectOne(selector string) (*Root, error) {
+	roots, err := r.Select(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	return roots[0], nil
+}
+
+func parseSelector(s string) ([]selectorChain, error) {
+	groups := splitTopLevel(s, ',')
+	chains := make([]selectorChain, 0, len(groups))
+	for _, g := range groups {
+		chain, err := parseChain(g)
+		if err != nil {
+			return nil, err
+		}
+		chains = append(chains, chain)
+	}
+
+	return chains, nil
+}
+
+func splitTopLevel(s string, sep byte) []string {
+	var parts []string
+	depth := 0
+	inBracket := false
+	last := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case '[':
+			inBracket = true
+		case ']':
+			inBracket = false
+		}
+		if s[i] == sep && depth == 0 && !inBracket {
+			parts = append(parts, s[last:i])
+			last = i + 1
+		}
+	}
+	parts = append(parts, s[last:])
+
+	return parts
+}
+
+func parseChain(s string) (selectorChain, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, newError(ErrInvalidSelector, "empty selector")
+	}
+
+	var steps []selectorStep
+	comb := combDescendant
+	sawCombinator := false
+	i := 0
+	for i < len(s) {
+		for i < len(s) && s[i] == ' ' {
+			i++
+		}
+		if i >= len(s) {
+			break
+		}
+
+		if s[i] == '>' || s[i] == '+' || s[i] == '~' {
+			switch s[i] {
+			case '>':
+				comb = combChild
+			case '+':
+				comb = combAdjacent
+			case '~':
+				comb = combGeneral
+			}
+			sawCombinator = true
+			i++
+			continue
+		}
+
+		start := i
+		depth := 0
+		inBracket := false
+		for i < len(s) {
+			c := s[i]
+			if c == '(' {
+				depth++
+			} else if c == ')' {
+				depth--
+			} else if c == '[' {
+				inBracket = true
+			} else if c == ']' {
+				inBracket = false
+			} else if depth == 0 && !inBracket && (c == ' ' || c == '>' || c == '+' || c == '~') {
+				break
+			}
+			i++
+		}
+
+		token := s[start:i]
+		if token == "" {
+			return nil, newError(ErrInvalidSelector, "empty compound selector")
+		}
+
+		compound, err := parseCompoundSelector(token)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(steps) == 0 {
+			if sawCombinator {
+				return nil, newError(ErrInvalidSelector, "selector starts with a dangling combinator")
+			}
+			steps = append(steps, selectorStep{compound: compound})
+		} else {
+			steps = append(steps, selectorStep{comb: comb, compound: compound})
+		}
+
+		comb = combDescendant
+		sawCombinator = false
+	}
+
+	if sawCombinator {
+		return nil, newError(ErrInvalidSelector, "selector ends with a dangling combinator")
+	}
+	if len(steps) == 0 {
+		return nil, newError(ErrInvalidSelector, "empty selector")
+	}
+
+	return steps, nil
+}
+
+func parseCompoundSelector(token string) (compoundSelector, error) {
+	var c compoundSelector
+
+	i := 0
+	n := len(token)
+	if i < n && token[i] != '.' && token[i] != '#' && token[i] != '[' && token[i] != ':' {
+		start := i
+		for i < n && token[i] != '.' && token[i] != '#' && token[i] != '[' && token[i] != ':' {
+			i++
+		}
+		c.tag = token[start:i]
+	}
+
+	for i < n {
+		switch token[i] {
+		case '#':
+			i++
+			start := i
+			for i < n && token[i] != '.' && token[i] != '[' && token[i] != ':' {
+				i++
+			}
+			if start == i {
+				return c, newError(ErrInvalidSelector, "empty id selector")
+			}
+			c.id = token[start:i]
+		case '.':
+			i++
+			start := i
+			for i < n && token[i] != '.' && token[i] != '[' && token[i] != ':' {
+				i++
+			}
+			if start == i {
+				return c, newError(ErrInvalidSelector, "empty class selector")
+			}
+			c.classes = append(c.classes, token[start:i])
+		case '[':
+			end := strings.IndexByte(token[i:], ']')
+			if end == -1 {
+				return c, newError(ErrInvalidSelector, "unterminated attribute selector")
+			}
+			am, err := parseAttrMatcher(token[i+1 : i+end])
+			if err != nil {
+				return c, err
+			}
+			c.attrs = append(c.attrs, am)
+			i = i + end + 1
+		case ':':
+			i++
+			start := i
+			for i < n && token[i] != '.' && token[i] != '[' && token[i] != ':' && token[i] != '(' {
+				i++
+			}
+			name := token[start:i]
+			if name == "" {
+				return c, newError(ErrInvalidSelector, "empty pseudo-class")
+			}
+
+			arg := ""
+			if i < n && token[i] == '(' {
+				end := strings.IndexByte(token[i:], ')')
+				if end == -1 {
+					return c, newError(ErrInvalidSelector, "unterminated pseudo-class")
+				}
+				arg = token[i+1 : i+end]
+				i = i + end + 1
+			}
+			c.pseudos = append(c.pseudos, pseudoMatcher{name: name, arg: arg})
+		default:
+			return c, newError(ErrInvalidSelector, fmt.Sprintf("unexpected character `%c` in selector", token[i]))
+		}
+	}
+
+	return c, nil
+}
+
+var attrOps = []string{"~=", "^=", "$=", "*=", "="}
+
+func parseAttrMatcher(body string) (attrMatcher, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return attrMatcher{}, newError(ErrInvalidSelector, "empty attribute selector")
+	}
+
+	for _, op := range attrOps {
+		if idx := strings.Index(body, op); idx >= 0 {
+			name := strings.TrimSpace(body[:idx])
+			val := strings.TrimSpace(body[idx+len(op):])
+			val = strings.Trim(val, `"'`)
+			return attrMatcher{name: name, op: op, val: val}, nil
+		}
+	}
+
+	return attrMatcher{name: body}, nil
+}
+
+func nodeMatchesCompound(n *html.Node, c compoundSelector) bool {
+	if n.Type != html.ElementNode {
+		return false
+	}
+	if c.tag != "" && c.tag != "*" && n.Data != c.tag {
+		return false
+	}
+
+	attrs := getKeyValue(n.Attr)
+	if c.id != "" && attrs["id"] != c.id {
+		return false
+	}
+
+	for _, class := range c.classes {
+		if !hasClassValue(attrs["class"], class) {
+			return false
+		}
+	}
+
+	for _, am := range c.attrs {
+		if !matchAttrMatcher(n, am) {
+			return false
+		}
+	}
+
+	for _, p := range c.pseudos {
+		if !matchPseudo(n, p) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func hasClassValue(classAttr, class string) bool {
+	for _, f := range strings.Fields(classAttr) {
+		if f == class {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchAttrMatcher(n *html.Node, am attrMatcher) bool {
+	for _, attr := range n.Attr {
+		if attr.Key != am.name {
+			continue
+		}
+		switch am.op {
+		case "":
+			return true
+		case "=":
+			return attr.Val == am.val
+		case "~=":
+			return hasClassValue(attr.Val, am.val)
+		case "^=":
+			return strings.HasPrefix(attr.Val, am.val)
+		case "$=":
+			return strings.HasSuffix(attr.Val, am.val)
+		case "*=":
+			return strings.Contains(attr.Val, am.val)
+		}
+	}
+
+	return false
+}
+
+func matchPseudo(n *html.Node, p pseudoMatcher) bool {
+	switch p.name {
+	case "first-child":
+		return elementIndex(n) == 0
+	case "last-child":
+		return nextElementSiblingNode(n) == nil
+	case "nth-child":
+		a, b, err := parseAnB(p.arg)
+		if err != nil {
+			return false
+		}
+		return matchAnB(elementIndex(n)+1, a, b)
+	case "not":
+		inner, err := parseCompoundSelector(strings.TrimSpace(p.arg))
+		if err != nil {
+			return false
+		}
+		return !nodeMatchesCompound(n, inner)
+	default:
+		return false
+	}
+}
+
+func elementIndex(n *html.Node) int {
+	idx := 0
+	for s := n.PrevSibling; s != nil; s = s.PrevSibling {
+		if s.Type == html.ElementNode {
+			idx++
+		}
+	}
+
+	return idx
+}
+
+func nextElementSiblingNode(n *html.Node) *html.Node {
+	for s := n.NextSibling; s != nil; s = s.NextSibling {
+		if s.Type == html.ElementNode {
+			return s
+		}
+	}
+
+	return nil
+}
+
+func prevElementSiblingNode(n *html.Node) *html.Node {
+	for s := n.PrevSibling; s != nil; s = s.PrevSibling {
+		if s.Type == html.ElementNode {
+			return s
+		}
+	}
+
+	return nil
+}
+
+func parseAnB(arg string) (int, int, error) {
+	arg = strings.TrimSpace(strings.ToLower(arg))
+
+	switch arg {
+	case "odd":
+		return 2, 1, nil
+	case "even":
+		return 2, 0, nil
+	}
+
+	if b, err := strconv.Atoi(arg); err == nil {
+		return 0, b, nil
+	}
+
+	m := anBRegexp.FindStringSubmatch(arg)
+	if m == nil {
+		return 0, 0, newError(ErrInvalidSelector, fmt.Sprintf("invalid an+b expression `%s`", arg))
+	}
+
+	a := 1
+	switch m[1] {
+	case "", "+":
+		a = 1
+	case "-":
+		a = -1
+	default:
+		n, err := strconv.Atoi(m[1])
+		if err != nil {
+			return 0, 0, newError(ErrInvalidSelector, fmt.Sprintf("invalid an+b expression `%s`", arg))
+		}
+		a = n
+	}
+
+	b := 0
+	if m[3] != "" {
+		n, err := strconv.Atoi(m[3])
+		if err != nil {
+			return 0, 0, newError(ErrInvalidSelector, fmt.Sprintf("invalid an+b expression `%s`", arg))
+		}
+		b = n
+		if m[2] == "-" {
+			b = -b
+		}
+	}
+
+	return a, b, nil
+}
+
+func matchAnB(index, a, b int) bool {
+	if a == 0 {
+		return index == b
+	}
+
+	d := index - b
+
+	return d%a == 0 && d/a >= 0
+}
+
+func matchChain(n *html.Node, chain selectorChain) bool {
+	i := len(chain) - 1
+	if !nodeMatchesCompound(n, chain[i].compound) {
+		return false
+	}
+
+	cur := n
+	for i > 0 {
+		comb := chain[i].comb
+		target := chain[i-1].compound
+
+		switch comb {
+		case combChild:
+			p := cur.Parent
+			if p == nil || !nodeMatchesCompound(p, target) {
+				return false
+			}
+			cur = p
+		case combDescendant:
+			p := cur.Parent
+			found := false
+			for p != nil {
+				if nodeMatchesCompound(p, target) {
+					found = true
+					cur = p
+					break
+				}
+				p = p.Parent
+			}
+			if !found {
+				return false
+			}
+		case combAdjacent:
+			s := prevElementSiblingNode(cur)
+			if s == nil || !nodeMatchesCompound(s, target) {
+				return false
+			}
+			cur = s
+		case combGeneral:
+			found := false
+			for s := prevElementSiblingNode(cur); s != nil; s = prevElementSiblingNode(s) {
+				if nodeMatchesCompound(s, target) {
+					found = true
+					cur = s
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		i--
+	}
+
+	return true
+}
@@ -0,0 +1,106 @@
+package gosoup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const selectHTML = `
+<html>
+  <body>
+    <div class="content">
+      <a href="/foo">Foo</a>
+      <a href="https://example.com">Example</a>
+      <span class="intro">Hi</span>
+    </div>
+    <ul id="list">
+      <li>One</li>
+      <li class="active">Two</li>
+      <li>Three</li>
+      <li>Four</li>
+    </ul>
+  </body>
+</html>
+`
+
+func TestSelectTagAndClass(t *testing.T) {
+	doc, err := HTMLParse(selectHTML)
+	assert.Nil(t, err)
+
+	actual, err := doc.Select("div.content > a[href^=\"/\"]")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(actual))
+	assert.Equal(t, "Foo", actual[0].Text())
+}
+
+func TestSelectGrouping(t *testing.T) {
+	doc, err := HTMLParse(selectHTML)
+	assert.Nil(t, err)
+
+	actual, err := doc.Select(".intro, .active")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(actual))
+}
+
+func TestSelectNthChild(t *testing.T) {
+	doc, err := HTMLParse(selectHTML)
+	assert.Nil(t, err)
+
+	actual, err := doc.Select("#list li:nth-child(odd)")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(actual))
+	assert.Equal(t, "One", actual[0].Text())
+	assert.Equal(t, "Three", actual[1].Text())
+}
+
+func TestSelectFirstLastChild(t *testing.T) {
+	doc, err := HTMLParse(selectHTML)
+	assert.Nil(t, err)
+
+	first, err := doc.SelectOne("#list li:first-child")
+	assert.Nil(t, err)
+	assert.Equal(t, "One", first.Text())
+
+	last, err := doc.SelectOne("#list li:last-child")
+	assert.Nil(t, err)
+	assert.Equal(t, "Four", last.Text())
+}
+
+func TestSelectNot(t *testing.T) {
+	doc, err := HTMLParse(selectHTML)
+	assert.Nil(t, err)
+
+	actual, err := doc.Select("#list li:not(.active)")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(actual))
+}
+
+func TestSelectInvalidSelector(t *testing.T) {
+	doc, err := HTMLParse(selectHTML)
+	assert.Nil(t, err)
+
+	_, err = doc.Select("div >")
+	assert.NotNil(t, err)
+	assert.Equal(t, ErrInvalidSelector, err.(Error).Type)
+}
+
+func TestSelectLeadingCombinatorIsInvalid(t *testing.T) {
+	doc, err := HTMLParse(selectHTML)
+	assert.Nil(t, err)
+
+	for _, selector := range []string{"> div", "+div", "~div"} {
+		_, err = doc.Select(selector)
+		assert.NotNil(t, err)
+		assert.Equal(t, ErrInvalidSelector, err.(Error).Type)
+	}
+}
+
+func TestSelectNoMatch(t *testing.T) {
+	doc, err := HTMLParse(selectHTML)
+	assert.Nil(t, err)
+
+	_, err = doc.Select(".nope")
+	assert.NotNil(t, err)
+	assert.Equal(t, ErrElementNotFound, err.(Error).Type)
+}
@@ -0,0 +1,227 @@
+package gosoup
+
+import (
+	"fmt"
+
+	"golang.org/x/net/html"
+)
+
+func (r Root) Parent() *Root {
+	if r.Node == nil || r.Node.Parent == nil {
+		return &Root{Error: newError(ErrElementNotFound, "no parent found")}
+	}
+
+	p := r.Node.Parent
+
+	return &Root{Node: p, Value: p.Data}
+}
+
+// Parents returns every ancestor of r, nearest first.
+func (r Root) Parents() []*Root {
+	if r.Node == nil {
+		return nil
+	}
+
+	var parents []*Root
+	for p := r.Node.Parent; p != nil; p = p.Parent {
+		parents = append(parents, &Root{Node: p, Value: p.Data})
+	}
+
+	return parents
+}
+
+// ParentsUntil returns the ancestors of r up to, but not including, the
+// nearest ancestor matching selector.
+func (r Root) ParentsUntil(selector string) ([]*Root, error) {
+	if r.Node == nil {
+		return nil, errNodeElementEmpty
+	}
+
+	chains, err := parseSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*Root
+	for p := r.Node.Parent; p != nil; p = p.Parent {
+		if matchesAnyChain(p, chains) {
+			break
+		}
+		result = append(result, &Root{Node: p, Value: p.Data})
+	}
+
+	return result, nil
+}
+
+// Closest returns the nearest ancestor of r (including r itself) matching
+// selector.
+func (r Root) Closest(selector string) (*Root, error) {
+	chains, err := parseSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	for n := r.Node; n != nil; n = n.Parent {
+		if matchesAnyChain(n, chains) {
+			return &Root{Node: n, Value: n.Data}, nil
+		}
+	}
+
+	return nil, newError(ErrElementNotFound, fmt.Sprintf("no ancestor matches selector `%s`", selector))
+}
+
+// Siblings returns every element sibling of r, excluding r itself.
+func (r Root) Siblings() []*Root {
+	var siblings []*Root
+	if r.Node == nil || r.Node.Parent == nil {
+		return siblings
+	}
+
+	for c := r.Node.Parent.FirstChild; c != nil; c = c.NextSibling {
+		if c == r.Node || c.Type != html.ElementNode {
+			continue
+		}
+		siblings = append(siblings, &Root{Node: c, Value: c.Data})
+	}
+
+	return siblings
+}
+
+func (r Root) NextAll() []*Root {
+	if r.Node == nil {
+		return nil
+	}
+
+	var result []*Root
+	for s := r.Node.NextSibling; s != nil; s = s.NextSibling {
+		if s.Type == html.ElementNode {
+			result = append(result, &Root{Node: s, Value: s.Data})
+		}
+	}
+
+	return result
+}
+
+func (r Root) PrevAll() []*Root {
+	if r.Node == nil {
+		return nil
+	}
+
+	var result []*Root
+	for s := r.Node.PrevSibling; s != nil; s = s.PrevSibling {
+		if s.Type == html.ElementNode {
+			result = append(result, &Root{Node: s, Value: s.Data})
+		}
+	}
+
+	return result
+}
+
+func (r Root) NextUntil(selector string) ([]*Root, error) {
+	if r.Node == nil {
+		return nil, errNodeElementEmpty
+	}
+
+	chains, err := parseSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*Root
+	for s := r.Node.NextSibling; s != nil; s = s.NextSibling {
+		if s.Type != html.ElementNode {
+			continue
+		}
+		if matchesAnyChain(s, chains) {
+			break
+		}
+		result = append(result, &Root{Node: s, Value: s.Data})
+	}
+
+	return result, nil
+}
+
+func (r Root) PrevUntil(selector string) ([]*Root, error) {
+	if r.Node == nil {
+		return nil, errNodeElementEmpty
+	}
+
+	chains, err := parseSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*Root
+	for s := r.Node.PrevSibling; s != nil; s = s.PrevSibling {
+		if s.Type != html.ElementNode {
+			continue
+		}
+		if matchesAnyChain(s, chains) {
+			break
+		}
+		result = append(result, &Root{Node: s, Value: s.Data})
+	}
+
+	return result, nil
+}
+
+// Filter returns the subset of roots matching selector.
+func Filter(roots []*Root, selector string) ([]*Root, error) {
+	chains, err := parseSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*Root
+	for _, r := range roots {
+		if r.Node != nil && matchesAnyChain(r.Node, chains) {
+			result = append(result, r)
+		}
+	}
+
+	return result, nil
+}
+
+// Not returns the subset of roots that do not match selector.
+func Not(roots []*Root, selector string) ([]*Root, error) {
+	chains, err := parseSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*Root
+	for _, r := range roots {
+		if r.Node == nil || !matchesAnyChain(r.Node, chains) {
+			result = append(result, r)
+		}
+	}
+
+	return result, nil
+}
+
+// Each calls fn for every root in roots, in order.
+func Each(roots []*Root, fn func(i int, r *Root)) {
+	for i, r := range roots {
+		fn(i, r)
+	}
+}
+
+// Index returns r's position among its element siblings, or -1 if r has no
+// node.
+func (r Root) Index() int {
+	if r.Node == nil {
+		return -1
+	}
+
+	return elementIndex(r.Node)
+}
+
+func matchesAnyChain(n *html.Node, chains []selectorChain) bool {
+	for _, chain := range chains {
+		if matchChain(n, chain) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,150 @@
+package gosoup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const traverseHTML = `
+<html>
+  <body>
+    <table>
+      <caption>Scores</caption>
+      <tr><td class="cell">1</td></tr>
+      <tr><td class="cell">2</td></tr>
+      <tr><td class="cell">3</td></tr>
+    </table>
+  </body>
+</html>
+`
+
+func TestParentAndParents(t *testing.T) {
+	doc, err := HTMLParse(traverseHTML)
+	assert.Nil(t, err)
+
+	td := doc.Find("td")
+	assert.Equal(t, "tr", td.Parent().Value)
+
+	parents := td.Parents()
+	var tags []string
+	for _, p := range parents {
+		tags = append(tags, p.Value)
+	}
+	assert.Contains(t, tags, "table")
+	assert.Contains(t, tags, "body")
+	assert.Contains(t, tags, "html")
+}
+
+func TestClosest(t *testing.T) {
+	doc, err := HTMLParse(traverseHTML)
+	assert.Nil(t, err)
+
+	td := doc.Find("td")
+	table, err := td.Closest("table")
+	assert.Nil(t, err)
+	assert.Equal(t, "Scores", table.Find("caption").Text())
+
+	_, err = td.Closest("section")
+	assert.NotNil(t, err)
+}
+
+func TestSiblingsNextAllPrevAll(t *testing.T) {
+	doc, err := HTMLParse(traverseHTML)
+	assert.Nil(t, err)
+
+	rows, err := doc.FindAll("tr")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(rows))
+
+	middle := rows[1]
+	assert.Equal(t, 2, len(middle.Siblings()))
+	assert.Equal(t, 1, len(middle.NextAll()))
+	assert.Equal(t, 1, len(middle.PrevAll()))
+}
+
+func TestFilterAndNot(t *testing.T) {
+	doc, err := HTMLParse(traverseHTML)
+	assert.Nil(t, err)
+
+	cells, err := doc.FindAll("td")
+	assert.Nil(t, err)
+
+	filtered, err := Filter(cells, ".cell")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(filtered))
+
+	remaining, err := Not(cells, ".cell")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(remaining))
+}
+
+func TestNextUntilAndPrevUntil(t *testing.T) {
+	doc, err := HTMLParse(traverseHTML)
+	assert.Nil(t, err)
+
+	rows, err := doc.FindAll("tr")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(rows))
+
+	next, err := rows[0].NextUntil("tr:last-child")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(next))
+
+	prev, err := rows[2].PrevUntil("tr:first-child")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(prev))
+}
+
+func TestParentsUntil(t *testing.T) {
+	doc, err := HTMLParse(traverseHTML)
+	assert.Nil(t, err)
+
+	td := doc.Find("td")
+	parents, err := td.ParentsUntil("table")
+	assert.Nil(t, err)
+
+	var tags []string
+	for _, p := range parents {
+		tags = append(tags, p.Value)
+	}
+	assert.Equal(t, []string{"tr", "tbody"}, tags)
+}
+
+func TestTraverseOnMissingNode(t *testing.T) {
+	doc, err := HTMLParse(traverseHTML)
+	assert.Nil(t, err)
+
+	missing := doc.Find("section")
+	assert.NotNil(t, missing.Error)
+
+	assert.Nil(t, missing.Parents())
+	assert.Nil(t, missing.Siblings())
+	assert.Nil(t, missing.NextAll())
+	assert.Nil(t, missing.PrevAll())
+	assert.Equal(t, -1, missing.Index())
+
+	_, err = missing.ParentsUntil("table")
+	assert.NotNil(t, err)
+
+	_, err = missing.NextUntil("table")
+	assert.NotNil(t, err)
+
+	_, err = missing.PrevUntil("table")
+	assert.NotNil(t, err)
+}
+
+func TestEachAndIndex(t *testing.T) {
+	doc, err := HTMLParse(traverseHTML)
+	assert.Nil(t, err)
+
+	rows, err := doc.FindAll("tr")
+	assert.Nil(t, err)
+
+	var texts []string
+	Each(rows, func(i int, r *Root) {
+		texts = append(texts, r.Find("td").Text())
+		assert.Equal(t, i, r.Index())
+	})
+	assert.Equal(t, []string{"1", "2", "3"}, texts)
+}
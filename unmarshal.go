@@ -0,0 +1,166 @@
+package gosoup
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Unmarshal populates v, a pointer to a struct, from root using each
+// field's `css` and `extract` tags. `css` holds a CSS3 selector, optionally
+// followed by `,required`; `extract` selects what to pull out of the
+// matched element: "text", "fulltext", "html", "attr:<name>", or "exists".
+// Slice fields of struct type are populated from every match; struct
+// fields recurse with the matched element as the new root. A missing
+// match is left at its zero value unless the field is marked required.
+func Unmarshal(root *Root, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return newError(ErrUnableToParse, "Unmarshal target must be a non-nil pointer to a struct")
+	}
+
+	return unmarshalStruct(root, rv.Elem())
+}
+
+func unmarshalStruct(root *Root, sv reflect.Value) error {
+	st := sv.Type()
+	for i := 0; i < st.NumField(); i++ {
+		field := st.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		cssTag := field.Tag.Get("css")
+		if cssTag == "" {
+			continue
+		}
+
+		selector, required := parseCSSTag(cssTag)
+		extractTag := field.Tag.Get("extract")
+		fv := sv.Field(i)
+
+		if fv.Kind() == reflect.Slice {
+			if err := unmarshalSlice(root, selector, extractTag, required, fv); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if extractTag == "exists" && fv.Kind() == reflect.Bool {
+			_, err := root.SelectOne(selector)
+			if err != nil && (required || !isElementNotFound(err)) {
+				return err
+			}
+			fv.SetBool(err == nil)
+			continue
+		}
+
+		match, err := root.SelectOne(selector)
+		if err != nil {
+			if required || !isElementNotFound(err) {
+				return err
+			}
+			continue
+		}
+
+		if fv.Kind() == reflect.Struct {
+			if err := unmarshalStruct(match, fv); err != nil {
+				return err
+			}
+			continue
+		}
+
+		val, err := extractValue(match, extractTag)
+		if err != nil {
+			return err
+		}
+		setFieldValue(fv, val)
+	}
+
+	return nil
+}
+
+func unmarshalSlice(root *Root, selector, extractTag string, required bool, fv reflect.Value) error {
+	matches, err := root.Select(selector)
+	if err != nil {
+		if required || !isElementNotFound(err) {
+			return err
+		}
+		return nil
+	}
+
+	elemType := fv.Type().Elem()
+	slice := reflect.MakeSlice(fv.Type(), 0, len(matches))
+
+	for _, m := range matches {
+		if elemType.Kind() == reflect.Struct {
+			elem := reflect.New(elemType).Elem()
+			if err := unmarshalStruct(m, elem); err != nil {
+				return err
+			}
+			slice = reflect.Append(slice, elem)
+			continue
+		}
+
+		val, err := extractValue(m, extractTag)
+		if err != nil {
+			return err
+		}
+		elem := reflect.New(elemType).Elem()
+		setFieldValue(elem, val)
+		slice = reflect.Append(slice, elem)
+	}
+
+	fv.Set(slice)
+
+	return nil
+}
+
+func extractValue(r *Root, extractTag string) (interface{}, error) {
+	switch {
+	case extractTag == "" || extractTag == "text":
+		return r.Text(), nil
+	case extractTag == "fulltext":
+		return r.FullText(), nil
+	case extractTag == "html":
+		return r.HTML(), nil
+	case extractTag == "exists":
+		return true, nil
+	case strings.HasPrefix(extractTag, "attr:"):
+		return r.Attrs()[strings.TrimPrefix(extractTag, "attr:")], nil
+	default:
+		return nil, newError(ErrUnableToParse, fmt.Sprintf("unknown extract tag `%s`", extractTag))
+	}
+}
+
+func setFieldValue(fv reflect.Value, val interface{}) {
+	switch v := val.(type) {
+	case string:
+		if fv.Kind() == reflect.String {
+			fv.SetString(v)
+		}
+	case bool:
+		if fv.Kind() == reflect.Bool {
+			fv.SetBool(v)
+		}
+	}
+}
+
+// isElementNotFound reports whether err is the "no match" error Select and
+// SelectOne return, as opposed to e.g. ErrInvalidSelector from a malformed
+// css tag, which must not be swallowed by an optional field.
+func isElementNotFound(err error) bool {
+	gerr, ok := err.(Error)
+	return ok && gerr.Type == ErrElementNotFound
+}
+
+// parseCSSTag splits an optional trailing ",required" off a css tag. It
+// must look at the last comma, not the first, since the selector itself
+// may use CSS3 grouping commas (e.g. `css:"h1.a, h1.b"`).
+func parseCSSTag(tag string) (string, bool) {
+	if idx := strings.LastIndex(tag, ","); idx != -1 && strings.TrimSpace(tag[idx+1:]) == "required" {
+		return tag[:idx], true
+	}
+
+	return tag, false
+}
@@ -0,0 +1,134 @@
+package gosoup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const productHTML = `
+<html>
+  <body>
+    <h1 class="title">Widget</h1>
+    <div class="product">
+      <span class="name">Gizmo</span>
+    </div>
+    <ul class="links">
+      <li><a href="/a">A</a></li>
+      <li><a href="/b">B</a></li>
+    </ul>
+    <div class="badge">Sale</div>
+  </body>
+</html>
+`
+
+type Product struct {
+	Name string `css:"span.name" extract:"text"`
+}
+
+type Page struct {
+	Title   string   `css:"h1.title" extract:"text"`
+	Item    Product  `css:"div.product"`
+	Links   []string `css:"ul.links a[href]" extract:"attr:href"`
+	HasSale bool     `css:".badge" extract:"exists"`
+	Missing bool     `css:".nope" extract:"exists"`
+}
+
+func TestUnmarshal(t *testing.T) {
+	doc, err := HTMLParse(productHTML)
+	assert.Nil(t, err)
+
+	var page Page
+	assert.Nil(t, Unmarshal(doc, &page))
+
+	assert.Equal(t, "Widget", page.Title)
+	assert.Equal(t, "Gizmo", page.Item.Name)
+	assert.Equal(t, []string{"/a", "/b"}, page.Links)
+	assert.True(t, page.HasSale)
+	assert.False(t, page.Missing)
+}
+
+type RequiredPage struct {
+	Title string `css:"h1.missing,required" extract:"text"`
+}
+
+func TestUnmarshalRequiredMissing(t *testing.T) {
+	doc, err := HTMLParse(productHTML)
+	assert.Nil(t, err)
+
+	var page RequiredPage
+	err = Unmarshal(doc, &page)
+	assert.NotNil(t, err)
+}
+
+type OptionalPage struct {
+	Title string `css:"h1.missing" extract:"text"`
+}
+
+func TestUnmarshalOptionalMissingLeavesZeroValue(t *testing.T) {
+	doc, err := HTMLParse(productHTML)
+	assert.Nil(t, err)
+
+	var page OptionalPage
+	assert.Nil(t, Unmarshal(doc, &page))
+	assert.Equal(t, "", page.Title)
+}
+
+type ProductList struct {
+	Products []Product `css:"div.product"`
+}
+
+func TestUnmarshalSliceOfStructs(t *testing.T) {
+	doc, err := HTMLParse(productHTML)
+	assert.Nil(t, err)
+
+	var list ProductList
+	assert.Nil(t, Unmarshal(doc, &list))
+	assert.Equal(t, 1, len(list.Products))
+	assert.Equal(t, "Gizmo", list.Products[0].Name)
+}
+
+type GroupedSelectorPage struct {
+	Title string `css:"h1.nope, h1.title,required" extract:"text"`
+}
+
+func TestUnmarshalGroupedSelectorWithRequired(t *testing.T) {
+	doc, err := HTMLParse(productHTML)
+	assert.Nil(t, err)
+
+	var page GroupedSelectorPage
+	assert.Nil(t, Unmarshal(doc, &page))
+	assert.Equal(t, "Widget", page.Title)
+}
+
+type InvalidSelectorPage struct {
+	Title string `css:"h1..title" extract:"text"`
+}
+
+func TestUnmarshalInvalidSelectorIsNotSwallowed(t *testing.T) {
+	doc, err := HTMLParse(productHTML)
+	assert.Nil(t, err)
+
+	var page InvalidSelectorPage
+	err = Unmarshal(doc, &page)
+	assert.NotNil(t, err)
+}
+
+type InvalidSelectorSlicePage struct {
+	Items []string `css:"div..item" extract:"text"`
+}
+
+func TestUnmarshalSliceInvalidSelectorIsNotSwallowed(t *testing.T) {
+	doc, err := HTMLParse(productHTML)
+	assert.Nil(t, err)
+
+	var page InvalidSelectorSlicePage
+	err = Unmarshal(doc, &page)
+	assert.NotNil(t, err)
+}
+
+func TestUnmarshalRequiresPointerToStruct(t *testing.T) {
+	var page Page
+	err := Unmarshal(nil, page)
+	assert.NotNil(t, err)
+}